@@ -6,8 +6,10 @@ import (
 	"crypto/sha1"
 	"encoding/hex"
 	"fmt"
+	"io"
 	"io/ioutil"
 	"strconv"
+	"strings"
 	"time"
 )
 
@@ -122,7 +124,16 @@ func NewSignature(signature []byte) Signature {
 				if err == nil {
 					ret.When = time.Unix(t, 0)
 				}
-				end = true
+				if !end {
+					state = 'z'
+					from = i + 1
+				}
+			}
+		case 'z':
+			if end && i > from {
+				if loc, err := parseTimezone(signature[from:i]); err == nil {
+					ret.When = ret.When.In(loc)
+				}
 			}
 		}
 
@@ -138,13 +149,72 @@ func (s *Signature) String() string {
 	return fmt.Sprintf("%q <%s> @ %s", s.Name, s.Email, s.When)
 }
 
+// parseTimezone parses a git timezone offset, e.g. "+0200" or "-0530",
+// into a fixed *time.Location.
+func parseTimezone(b []byte) (*time.Location, error) {
+	if len(b) != 5 {
+		return nil, fmt.Errorf("invalid timezone offset %q", b)
+	}
+
+	sign := 1
+	switch b[0] {
+	case '+':
+	case '-':
+		sign = -1
+	default:
+		return nil, fmt.Errorf("invalid timezone offset %q", b)
+	}
+
+	hh, err := strconv.Atoi(string(b[1:3]))
+	if err != nil {
+		return nil, fmt.Errorf("invalid timezone offset %q", b)
+	}
+
+	mm, err := strconv.Atoi(string(b[3:5]))
+	if err != nil {
+		return nil, fmt.Errorf("invalid timezone offset %q", b)
+	}
+
+	return time.FixedZone(string(b), sign*(hh*3600+mm*60)), nil
+}
+
 type Tree struct {
 	Entries []TreeEntry
 	hash    string
 }
 
+// FileMode is the octal file mode that precedes each entry in a tree
+// object.
+type FileMode int
+
+const (
+	TreeEntryMode       FileMode = 0040000
+	BlobEntryMode       FileMode = 0100644
+	ExecutableEntryMode FileMode = 0100755
+	SymlinkEntryMode    FileMode = 0120000
+	GitlinkEntryMode    FileMode = 0160000
+)
+
+func (m FileMode) String() string {
+	switch m {
+	case TreeEntryMode:
+		return "tree"
+	case BlobEntryMode:
+		return "blob"
+	case ExecutableEntryMode:
+		return "executable"
+	case SymlinkEntryMode:
+		return "symlink"
+	case GitlinkEntryMode:
+		return "gitlink"
+	default:
+		return fmt.Sprintf("%06o", int(m))
+	}
+}
+
 type TreeEntry struct {
 	Name string
+	Mode FileMode
 	Hash string
 }
 
@@ -170,8 +240,14 @@ func NewTree(b []byte) (*Tree, error) {
 		split := bytes.SplitN(body, []byte{0}, 2)
 		split1 := bytes.SplitN(split[0], []byte{' '}, 2)
 
+		mode, err := strconv.ParseInt(string(split1[0]), 8, 32)
+		if err != nil {
+			return nil, err
+		}
+
 		o.Entries = append(o.Entries, TreeEntry{
 			Name: string(split1[1]),
+			Mode: FileMode(mode),
 			Hash: fmt.Sprintf("%x", split[1][0:20]),
 		})
 
@@ -192,13 +268,17 @@ func (o *Tree) Hash() string {
 	return o.hash
 }
 
+// Blob is a lazy reference to the content of a blob object: its content
+// is never held in memory, only read on demand through Reader.
 type Blob struct {
-	Len  int
-	hash string
+	hash    string
+	storage ObjectStorage
 }
 
-func NewBlob(b []byte) (*Blob, error) {
-	return &Blob{Len: len(b), hash: calculateHash("blob", b)}, nil
+// NewBlob returns the Blob identified by hash, whose content is read
+// from storage on demand.
+func NewBlob(storage ObjectStorage, hash string) *Blob {
+	return &Blob{hash: hash, storage: storage}
 }
 
 func (o *Blob) Type() string {
@@ -209,6 +289,126 @@ func (o *Blob) Hash() string {
 	return o.hash
 }
 
+// Reader opens the blob's content, reading it from its backing storage.
+func (o *Blob) Reader() (io.ReadCloser, error) {
+	_, r, err := o.storage.Get(o.hash)
+	return r, err
+}
+
+const pgpSignatureHeader = "-----BEGIN PGP SIGNATURE-----"
+
+type Tag struct {
+	Object Hash
+	// TargetType is the type of the object the tag points to. It is
+	// named TargetType, rather than Type, to avoid clashing with the
+	// Type() method required by the Object interface.
+	TargetType string
+	Tag        string
+	Tagger     Signature
+	Message    string
+	Signature  string
+	hash       string
+}
+
+func NewTag(b []byte) (*Tag, error) {
+	o := &Tag{hash: calculateHash("tag", b)}
+
+	lines := bytes.Split(b, []byte{'\n'})
+	for i := range lines {
+		if len(lines[i]) > 0 {
+			var err error
+
+			split := bytes.SplitN(lines[i], []byte{' '}, 2)
+			if len(split) != 2 {
+				continue
+			}
+
+			switch string(split[0]) {
+			case "object":
+				o.Object = make([]byte, 20)
+				_, err = hex.Decode(o.Object, split[1])
+			case "type":
+				o.TargetType = string(split[1])
+			case "tag":
+				o.Tag = string(split[1])
+			case "tagger":
+				o.Tagger = NewSignature(split[1])
+			}
+
+			if err != nil {
+				return nil, err
+			}
+		} else {
+			body := string(bytes.Join(append(lines[i+1:]), []byte{'\n'}))
+			if idx := strings.Index(body, pgpSignatureHeader); idx != -1 {
+				o.Message = strings.TrimSuffix(body[:idx], "\n")
+				o.Signature = body[idx:]
+			} else {
+				o.Message = body
+			}
+			break
+		}
+	}
+
+	return o, nil
+}
+
+func (o *Tag) Type() string {
+	return "tag"
+}
+
+func (o *Tag) Hash() string {
+	return o.hash
+}
+
+// object type codes as used inside a packfile, see
+// https://git-scm.com/docs/pack-format.
+const (
+	ObjCommit = 1
+	ObjTree   = 2
+	ObjBlob   = 3
+	ObjTag    = 4
+)
+
+// NewObject dispatches to the right constructor for objType, one of
+// "commit", "tree" or "tag". Blob objects have no content-based
+// constructor: they are read lazily through an ObjectStorage instead,
+// see NewBlob.
+func NewObject(objType string, b []byte) (Object, error) {
+	switch objType {
+	case "commit":
+		return NewCommit(b)
+	case "tree":
+		return NewTree(b)
+	case "tag":
+		return NewTag(b)
+	default:
+		return nil, fmt.Errorf("object type %q has no content-based constructor", objType)
+	}
+}
+
+// NewObjectFromTypeCode dispatches to the right constructor based on the
+// numeric object type code used inside a packfile. See NewObject for
+// why blob objects (ObjBlob) aren't handled here.
+func NewObjectFromTypeCode(code int, b []byte) (Object, error) {
+	switch code {
+	case ObjCommit:
+		return NewCommit(b)
+	case ObjTree:
+		return NewTree(b)
+	case ObjTag:
+		return NewTag(b)
+	default:
+		return nil, fmt.Errorf("object type code %d has no content-based constructor", code)
+	}
+}
+
+// HashObject computes the canonical git hash for an object of the given
+// type holding content, i.e. the SHA-1 of "type len\x00content".
+func HashObject(objType string, content []byte) string {
+	return calculateHash(objType, content)
+}
+
 func calculateHash(objType string, content []byte) string {
 	header := []byte(objType)
 	header = append(header, ' ')
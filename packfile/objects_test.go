@@ -0,0 +1,136 @@
+package packfile
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	. "gopkg.in/check.v1"
+)
+
+func Test(t *testing.T) { TestingT(t) }
+
+type ObjectsSuite struct{}
+
+var _ = Suite(&ObjectsSuite{})
+
+func (s *ObjectsSuite) TestNewTag(c *C) {
+	raw := strings.Join([]string{
+		"object aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa",
+		"type commit",
+		"tag v1.0.0",
+		"tagger A Tagger <tagger@example.com> 1234567890 +0000",
+		"",
+		"Release v1.0.0",
+	}, "\n")
+
+	tag, err := NewTag([]byte(raw))
+	c.Assert(err, IsNil)
+	c.Assert(tag.Object.String(), Equals, "aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa")
+	c.Assert(tag.TargetType, Equals, "commit")
+	c.Assert(tag.Tag, Equals, "v1.0.0")
+	c.Assert(tag.Tagger.Name, Equals, "A Tagger")
+	c.Assert(tag.Message, Equals, "Release v1.0.0")
+	c.Assert(tag.Signature, Equals, "")
+	c.Assert(tag.Type(), Equals, "tag")
+}
+
+func (s *ObjectsSuite) TestNewTagSkipsMalformedHeaderLine(c *C) {
+	raw := "object\ntype commit\n\nhi"
+
+	tag, err := NewTag([]byte(raw))
+	c.Assert(err, IsNil)
+	c.Assert(tag.TargetType, Equals, "commit")
+	c.Assert(tag.Message, Equals, "hi")
+}
+
+func (s *ObjectsSuite) TestNewTagWithSignature(c *C) {
+	sig := "-----BEGIN PGP SIGNATURE-----\n...\n-----END PGP SIGNATURE-----"
+	raw := strings.Join([]string{
+		"object aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa",
+		"type commit",
+		"tag v1.0.0",
+		"tagger A Tagger <tagger@example.com> 1234567890 +0000",
+		"",
+		"Release v1.0.0\n" + sig,
+	}, "\n")
+
+	tag, err := NewTag([]byte(raw))
+	c.Assert(err, IsNil)
+	c.Assert(tag.Message, Equals, "Release v1.0.0")
+	c.Assert(tag.Signature, Equals, sig)
+}
+
+func (s *ObjectsSuite) TestFileModeString(c *C) {
+	c.Assert(TreeEntryMode.String(), Equals, "tree")
+	c.Assert(BlobEntryMode.String(), Equals, "blob")
+	c.Assert(ExecutableEntryMode.String(), Equals, "executable")
+	c.Assert(SymlinkEntryMode.String(), Equals, "symlink")
+	c.Assert(GitlinkEntryMode.String(), Equals, "gitlink")
+	c.Assert(FileMode(0).String(), Equals, "000000")
+}
+
+func (s *ObjectsSuite) TestNewTreeParsesMode(c *C) {
+	var raw bytes.Buffer
+	raw.WriteString("100644 file.txt")
+	raw.WriteByte(0)
+	raw.Write(bytes.Repeat([]byte{0xAB}, 20))
+	raw.WriteString("40000 dir")
+	raw.WriteByte(0)
+	raw.Write(bytes.Repeat([]byte{0xCD}, 20))
+
+	tree, err := NewTree(raw.Bytes())
+	c.Assert(err, IsNil)
+	c.Assert(tree.Entries, HasLen, 2)
+	c.Assert(tree.Entries[0].Name, Equals, "file.txt")
+	c.Assert(tree.Entries[0].Mode, Equals, BlobEntryMode)
+	c.Assert(tree.Entries[1].Name, Equals, "dir")
+	c.Assert(tree.Entries[1].Mode, Equals, TreeEntryMode)
+}
+
+func (s *ObjectsSuite) TestParseTimezone(c *C) {
+	loc, err := parseTimezone([]byte("+0200"))
+	c.Assert(err, IsNil)
+	_, offset := time.Unix(0, 0).In(loc).Zone()
+	c.Assert(offset, Equals, 2*3600)
+
+	loc, err = parseTimezone([]byte("-0530"))
+	c.Assert(err, IsNil)
+	_, offset = time.Unix(0, 0).In(loc).Zone()
+	c.Assert(offset, Equals, -(5*3600 + 30*60))
+
+	_, err = parseTimezone([]byte("bogus"))
+	c.Assert(err, Not(IsNil))
+}
+
+func (s *ObjectsSuite) TestNewSignatureParsesTimezone(c *C) {
+	sig := NewSignature([]byte("A U Thor <author@example.com> 1234567890 +0200"))
+	c.Assert(sig.Name, Equals, "A U Thor")
+	c.Assert(sig.Email, Equals, "author@example.com")
+
+	_, offset := sig.When.Zone()
+	c.Assert(offset, Equals, 2*3600)
+}
+
+func (s *ObjectsSuite) TestHashObject(c *C) {
+	c.Assert(HashObject("blob", []byte("hello world")), Equals, "95d09f2b10159347eece71399a7e2e907ea3df4f")
+}
+
+func (s *ObjectsSuite) TestNewObjectDispatchesKnownTypes(c *C) {
+	tree, err := NewObject("tree", []byte{})
+	c.Assert(err, IsNil)
+	c.Assert(tree.Type(), Equals, "tree")
+
+	_, err = NewObject("blob", []byte("content"))
+	c.Assert(err, Not(IsNil))
+}
+
+func (s *ObjectsSuite) TestNewObjectFromTypeCodeDispatchesKnownTypes(c *C) {
+	tree, err := NewObjectFromTypeCode(ObjTree, []byte{})
+	c.Assert(err, IsNil)
+	c.Assert(tree.Type(), Equals, "tree")
+
+	_, err = NewObjectFromTypeCode(ObjBlob, []byte("content"))
+	c.Assert(err, Not(IsNil))
+}
@@ -0,0 +1,31 @@
+package packfile
+
+import "io"
+
+// ObjectStorage is a backend capable of storing and retrieving git
+// objects by hash. Blob relies on one to read its content lazily; see
+// NewBlob.
+//
+// No code in this repository decodes a .pack file: NewCommit, NewTree
+// and NewTag parse object content that is already inflated. Of the
+// implementations in package storage, LooseStorage streams blob content
+// from disk on Get, while MemoryStorage keeps all content in memory.
+type ObjectStorage interface {
+	// Get returns the object identified by hash along with a reader
+	// over its content.
+	Get(hash string) (Object, io.ReadCloser, error)
+	// Put stores o, reading its content from r, and returns its hash.
+	Put(o Object, r io.Reader) (string, error)
+	// Iter returns an iterator over every stored object of objType.
+	Iter(objType string) (ObjectIter, error)
+	// Has reports whether an object with the given hash is stored.
+	Has(hash string) bool
+}
+
+// ObjectIter iterates over a sequence of objects returned by
+// ObjectStorage.Iter.
+type ObjectIter interface {
+	// Next returns the next object, or io.EOF once the iterator is
+	// exhausted.
+	Next() (Object, error)
+}
@@ -0,0 +1,111 @@
+// Package pktline implements reading and writing of the pkt-line wire
+// format used by the git smart protocols, as described in
+// https://git-scm.com/docs/protocol-common#_pkt_line_format.
+package pktline
+
+import (
+	"errors"
+	"io"
+	"strconv"
+)
+
+// MaxPayload is the maximum length, in bytes, of a pkt-line payload.
+const MaxPayload = 65516
+
+var (
+	// ErrInvalidLength is returned by ReadLine when the 4-byte length
+	// prefix cannot be parsed, or encodes a length that is neither a
+	// special value (flush, delim) nor big enough to hold itself.
+	ErrInvalidLength = errors.New("invalid length")
+	// ErrUnexepectedStringLength is returned by ReadLine when the
+	// length prefix promises more data than the underlying reader
+	// actually has.
+	ErrUnexepectedStringLength = errors.New("unexepected string length")
+	// ErrFlush is returned by ReadLine when it reads a flush-pkt
+	// ("0000"), the sentinel git uses to terminate a block of lines.
+	ErrFlush = errors.New("flush-pkt")
+	// ErrDelim is returned by ReadLine when it reads a delim-pkt
+	// ("0001"), the section separator introduced by protocol v2.
+	ErrDelim = errors.New("delim-pkt")
+)
+
+// Decoder reads pkt-lines from an underlying io.Reader.
+type Decoder struct {
+	r io.Reader
+}
+
+// NewDecoder returns a Decoder that reads pkt-lines from r.
+func NewDecoder(r io.Reader) *Decoder {
+	return &Decoder{r}
+}
+
+// ReadLine reads a single pkt-line and returns its payload. It returns
+// ErrFlush or ErrDelim, without consuming any further data, if the line
+// read is a flush-pkt or a delim-pkt.
+func (d *Decoder) ReadLine() (string, error) {
+	length := make([]byte, 4)
+	if _, err := io.ReadFull(d.r, length); err != nil {
+		return "", err
+	}
+
+	l, err := strconv.ParseInt(string(length), 16, 32)
+	if err != nil {
+		return "", ErrInvalidLength
+	}
+
+	switch l {
+	case 0:
+		return "", ErrFlush
+	case 1:
+		return "", ErrDelim
+	}
+
+	if l < 4 {
+		return "", ErrInvalidLength
+	}
+
+	payload := make([]byte, l-4)
+	if _, err := io.ReadFull(d.r, payload); err != nil {
+		return "", ErrUnexepectedStringLength
+	}
+
+	return string(payload), nil
+}
+
+// ReadBlock reads lines until it finds a flush-pkt or runs out of data,
+// returning every line read in between.
+func (d *Decoder) ReadBlock() ([]string, error) {
+	var lines []string
+	for {
+		line, err := d.ReadLine()
+		switch err {
+		case nil:
+			lines = append(lines, line)
+		case ErrFlush, io.EOF:
+			return lines, nil
+		default:
+			return nil, err
+		}
+	}
+}
+
+// ReadAll reads consecutive blocks until it reads an empty one, returning
+// every line read across all of them. A block is empty once the
+// underlying reader is exhausted, but also when a flush-pkt directly
+// follows another one or starts the data, so lines after such a pair
+// are not read.
+func (d *Decoder) ReadAll() ([]string, error) {
+	var lines []string
+	for {
+		block, err := d.ReadBlock()
+		if err != nil {
+			return nil, err
+		}
+
+		if len(block) == 0 {
+			return lines, nil
+		}
+
+		lines = append(lines, block...)
+	}
+}
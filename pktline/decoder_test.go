@@ -30,13 +30,29 @@ func (s *DecoderSuite) TestReadLineBufferUnderflow(c *C) {
 }
 
 func (s *DecoderSuite) TestReadLineBufferInvalidLen(c *C) {
-	j := &Decoder{strings.NewReader("0001foo\n")}
+	j := &Decoder{strings.NewReader("0003foo\n")}
 
 	line, err := j.ReadLine()
 	c.Assert(err, ErrorMatches, "invalid length")
 	c.Assert(line, Equals, "")
 }
 
+func (s *DecoderSuite) TestReadLineFlush(c *C) {
+	j := &Decoder{strings.NewReader("0000")}
+
+	line, err := j.ReadLine()
+	c.Assert(err, Equals, ErrFlush)
+	c.Assert(line, Equals, "")
+}
+
+func (s *DecoderSuite) TestReadLineDelim(c *C) {
+	j := &Decoder{strings.NewReader("0001")}
+
+	line, err := j.ReadLine()
+	c.Assert(err, Equals, ErrDelim)
+	c.Assert(line, Equals, "")
+}
+
 func (s *DecoderSuite) TestReadBlock(c *C) {
 	j := &Decoder{strings.NewReader("0006a\n")}
 
@@ -0,0 +1,50 @@
+package pktline
+
+import (
+	"errors"
+	"fmt"
+	"io"
+)
+
+// ErrPayloadTooLong is returned by WriteLine when the payload is longer
+// than MaxPayload.
+var ErrPayloadTooLong = errors.New("payload is too long")
+
+// Encoder writes pkt-lines to an underlying io.Writer.
+type Encoder struct {
+	w io.Writer
+}
+
+// NewEncoder returns an Encoder that writes pkt-lines to w.
+func NewEncoder(w io.Writer) *Encoder {
+	return &Encoder{w}
+}
+
+// WriteLine writes p as a single pkt-line, prefixed with its 4-byte
+// hexadecimal length (including the length prefix itself).
+func (e *Encoder) WriteLine(p []byte) (int, error) {
+	if len(p) > MaxPayload {
+		return 0, ErrPayloadTooLong
+	}
+
+	n, err := fmt.Fprintf(e.w, "%04x", len(p)+4)
+	if err != nil {
+		return n, err
+	}
+
+	m, err := e.w.Write(p)
+	return n + m, err
+}
+
+// WriteFlush writes a flush-pkt ("0000").
+func (e *Encoder) WriteFlush() error {
+	_, err := io.WriteString(e.w, "0000")
+	return err
+}
+
+// WriteDelim writes a delim-pkt ("0001"), the protocol v2 section
+// separator.
+func (e *Encoder) WriteDelim() error {
+	_, err := io.WriteString(e.w, "0001")
+	return err
+}
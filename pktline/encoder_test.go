@@ -0,0 +1,99 @@
+package pktline
+
+import (
+	"bytes"
+	"strings"
+
+	. "gopkg.in/check.v1"
+)
+
+type EncoderSuite struct{}
+
+var _ = Suite(&EncoderSuite{})
+
+func (s *EncoderSuite) TestWriteLine(c *C) {
+	var buf bytes.Buffer
+	e := NewEncoder(&buf)
+
+	n, err := e.WriteLine([]byte("a\n"))
+	c.Assert(err, IsNil)
+	c.Assert(n, Equals, 6)
+	c.Assert(buf.String(), Equals, "0006a\n")
+}
+
+func (s *EncoderSuite) TestWriteFlush(c *C) {
+	var buf bytes.Buffer
+	e := NewEncoder(&buf)
+
+	c.Assert(e.WriteFlush(), IsNil)
+	c.Assert(buf.String(), Equals, "0000")
+}
+
+func (s *EncoderSuite) TestWriteDelim(c *C) {
+	var buf bytes.Buffer
+	e := NewEncoder(&buf)
+
+	c.Assert(e.WriteDelim(), IsNil)
+	c.Assert(buf.String(), Equals, "0001")
+}
+
+func (s *EncoderSuite) TestWriteLineMaxPayload(c *C) {
+	var buf bytes.Buffer
+	e := NewEncoder(&buf)
+
+	payload := bytes.Repeat([]byte("a"), MaxPayload)
+	n, err := e.WriteLine(payload)
+	c.Assert(err, IsNil)
+	c.Assert(n, Equals, MaxPayload+4)
+	c.Assert(buf.String(), Equals, "fff0"+strings.Repeat("a", MaxPayload))
+}
+
+func (s *EncoderSuite) TestWriteLineTooLong(c *C) {
+	var buf bytes.Buffer
+	e := NewEncoder(&buf)
+
+	payload := bytes.Repeat([]byte("a"), MaxPayload+1)
+	_, err := e.WriteLine(payload)
+	c.Assert(err, Equals, ErrPayloadTooLong)
+}
+
+func (s *EncoderSuite) TestWriteLineBinaryPayload(c *C) {
+	var buf bytes.Buffer
+	e := NewEncoder(&buf)
+
+	payload := []byte{0x00, 'a', 0x00, 'b', 0x00}
+	_, err := e.WriteLine(payload)
+	c.Assert(err, IsNil)
+	c.Assert(buf.String(), Equals, "0009\x00a\x00b\x00")
+}
+
+func (s *EncoderSuite) TestRoundTrip(c *C) {
+	var buf bytes.Buffer
+	e := NewEncoder(&buf)
+
+	lines := [][]byte{
+		[]byte("first line\n"),
+		{0x00, 0x01, 0x02, 'x', 0x00},
+		bytes.Repeat([]byte("z"), MaxPayload),
+	}
+
+	for _, l := range lines {
+		_, err := e.WriteLine(l)
+		c.Assert(err, IsNil)
+	}
+	c.Assert(e.WriteDelim(), IsNil)
+	c.Assert(e.WriteFlush(), IsNil)
+
+	d := NewDecoder(&buf)
+	for i, l := range lines {
+		line, err := d.ReadLine()
+		c.Assert(err, IsNil)
+		c.Assert(line, Equals, string(l), Commentf("line %d", i))
+	}
+
+	_, err := d.ReadLine()
+	c.Assert(err, Equals, ErrDelim)
+
+	_, err = d.ReadLine()
+	c.Assert(err, Equals, ErrFlush)
+}
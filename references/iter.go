@@ -0,0 +1,44 @@
+package references
+
+import "io"
+
+// ReferenceIter iterates over a fixed set of References.
+type ReferenceIter struct {
+	refs []*Reference
+	pos  int
+}
+
+// NewReferenceIter returns an iterator over refs.
+func NewReferenceIter(refs []*Reference) *ReferenceIter {
+	return &ReferenceIter{refs: refs}
+}
+
+// Next returns the next Reference, or io.EOF once the iterator is
+// exhausted.
+func (i *ReferenceIter) Next() (*Reference, error) {
+	if i.pos >= len(i.refs) {
+		return nil, io.EOF
+	}
+
+	ref := i.refs[i.pos]
+	i.pos++
+	return ref, nil
+}
+
+// ForEach calls cb for every remaining Reference, stopping at the first
+// error it returns.
+func (i *ReferenceIter) ForEach(cb func(*Reference) error) error {
+	for {
+		ref, err := i.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		if err := cb(ref); err != nil {
+			return err
+		}
+	}
+}
@@ -0,0 +1,57 @@
+package references
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// ReadLoose walks gitDir/refs and returns every loose reference found
+// under it.
+func ReadLoose(gitDir string) ([]*Reference, error) {
+	root := filepath.Join(gitDir, "refs")
+
+	var refs []*Reference
+	err := filepath.Walk(root, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() {
+			return nil
+		}
+
+		rel, err := filepath.Rel(gitDir, path)
+		if err != nil {
+			return err
+		}
+
+		ref, err := readRefFile(filepath.ToSlash(rel), path)
+		if err != nil {
+			return err
+		}
+
+		refs = append(refs, ref)
+		return nil
+	})
+
+	if os.IsNotExist(err) {
+		return refs, nil
+	}
+
+	return refs, err
+}
+
+// ReadHEAD reads the HEAD pseudo-ref at the top of gitDir.
+func ReadHEAD(gitDir string) (*Reference, error) {
+	return readRefFile("HEAD", filepath.Join(gitDir, "HEAD"))
+}
+
+func readRefFile(name, path string) (*Reference, error) {
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewReference(name, strings.TrimSpace(string(b))), nil
+}
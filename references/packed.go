@@ -0,0 +1,57 @@
+package references
+
+import (
+	"bufio"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// ReadPacked parses gitDir/packed-refs, including the "^peeled" lines
+// that follow an annotated tag and the optional "# pack-refs with:"
+// header. It returns an empty slice, not an error, if the file doesn't
+// exist.
+func ReadPacked(gitDir string) ([]*Reference, error) {
+	f, err := os.Open(filepath.Join(gitDir, "packed-refs"))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	return parsePacked(f)
+}
+
+func parsePacked(r io.Reader) ([]*Reference, error) {
+	var refs []*Reference
+
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if line == "" {
+			continue
+		}
+
+		switch line[0] {
+		case '#':
+			// header, e.g. "# pack-refs with: peeled fully-peeled sorted"
+			continue
+		case '^':
+			if len(refs) == 0 {
+				continue
+			}
+			refs[len(refs)-1].Peeled = line[1:]
+		default:
+			parts := strings.SplitN(line, " ", 2)
+			if len(parts) != 2 {
+				continue
+			}
+			refs = append(refs, NewReference(parts[1], parts[0]))
+		}
+	}
+
+	return refs, scanner.Err()
+}
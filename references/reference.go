@@ -0,0 +1,85 @@
+// Package references provides types and helpers for reading git
+// references (branches, tags, notes and HEAD) from a repository's
+// loose refs directory and packed-refs file.
+package references
+
+import "strings"
+
+// RefType identifies what a Reference points at.
+type RefType int
+
+const (
+	// BranchRef is a reference under refs/heads/.
+	BranchRef RefType = iota
+	// TagRef is a reference under refs/tags/.
+	TagRef
+	// NoteRef is a reference under refs/notes/.
+	NoteRef
+	// SymbolicRef is a reference whose target is another ref name,
+	// such as HEAD usually being "ref: refs/heads/master".
+	SymbolicRef
+	// OtherRef is any reference that doesn't fall under the well
+	// known namespaces above.
+	OtherRef
+)
+
+func (t RefType) String() string {
+	switch t {
+	case BranchRef:
+		return "branch"
+	case TagRef:
+		return "tag"
+	case NoteRef:
+		return "note"
+	case SymbolicRef:
+		return "symbolic"
+	default:
+		return "other"
+	}
+}
+
+const symRefPrefix = "ref: "
+
+// Reference represents a single git reference: a name bound either to a
+// commit-ish hash or, if symbolic, to the name of another reference.
+type Reference struct {
+	// Name is the full reference name, e.g. "refs/heads/master".
+	Name string
+	// Type classifies the reference as a branch, tag, note, symbolic
+	// ref or other.
+	Type RefType
+	// Target is the hexadecimal hash the reference points to, or, if
+	// Type is SymbolicRef, the name of the reference it points to.
+	Target string
+	// Peeled is the hexadecimal hash an annotated tag ultimately
+	// points to, as recorded by a "^..." line in packed-refs. It is
+	// empty for references that aren't peeled annotated tags.
+	Peeled string
+}
+
+// NewReference builds the Reference for name given the raw contents of
+// its ref file (or its packed-refs line), minus any trailing newline.
+func NewReference(name, target string) *Reference {
+	if strings.HasPrefix(target, symRefPrefix) {
+		return &Reference{
+			Name:   name,
+			Type:   SymbolicRef,
+			Target: strings.TrimPrefix(target, symRefPrefix),
+		}
+	}
+
+	return &Reference{Name: name, Type: typeFromName(name), Target: target}
+}
+
+func typeFromName(name string) RefType {
+	switch {
+	case strings.HasPrefix(name, "refs/heads/"):
+		return BranchRef
+	case strings.HasPrefix(name, "refs/tags/"):
+		return TagRef
+	case strings.HasPrefix(name, "refs/notes/"):
+		return NoteRef
+	default:
+		return OtherRef
+	}
+}
@@ -0,0 +1,81 @@
+package references
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	. "gopkg.in/check.v1"
+)
+
+func Test(t *testing.T) { TestingT(t) }
+
+type ReferencesSuite struct{}
+
+var _ = Suite(&ReferencesSuite{})
+
+func (s *ReferencesSuite) TestNewReferenceBranch(c *C) {
+	ref := NewReference("refs/heads/master", "aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa")
+	c.Assert(ref.Type, Equals, BranchRef)
+	c.Assert(ref.Target, Equals, "aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa")
+	c.Assert(ref.Peeled, Equals, "")
+}
+
+func (s *ReferencesSuite) TestNewReferenceTag(c *C) {
+	ref := NewReference("refs/tags/v1.0.0", "bbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbb")
+	c.Assert(ref.Type, Equals, TagRef)
+}
+
+func (s *ReferencesSuite) TestNewReferenceSymbolic(c *C) {
+	ref := NewReference("HEAD", "ref: refs/heads/master")
+	c.Assert(ref.Type, Equals, SymbolicRef)
+	c.Assert(ref.Target, Equals, "refs/heads/master")
+}
+
+func (s *ReferencesSuite) TestParsePacked(c *C) {
+	data := strings.Join([]string{
+		"# pack-refs with: peeled fully-peeled sorted",
+		"aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa refs/heads/master",
+		"bbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbb refs/tags/v1.0.0",
+		"^cccccccccccccccccccccccccccccccccccccccc",
+		"",
+	}, "\n")
+
+	refs, err := parsePacked(strings.NewReader(data))
+	c.Assert(err, IsNil)
+	c.Assert(refs, HasLen, 2)
+	c.Assert(refs[0].Name, Equals, "refs/heads/master")
+	c.Assert(refs[0].Peeled, Equals, "")
+	c.Assert(refs[1].Name, Equals, "refs/tags/v1.0.0")
+	c.Assert(refs[1].Peeled, Equals, "cccccccccccccccccccccccccccccccccccccccc")
+}
+
+func (s *ReferencesSuite) TestReadLooseAndResolveSymbolic(c *C) {
+	dir, err := ioutil.TempDir("", "go-git-references")
+	c.Assert(err, IsNil)
+	defer os.RemoveAll(dir)
+
+	c.Assert(os.MkdirAll(filepath.Join(dir, "refs", "heads"), 0755), IsNil)
+	c.Assert(ioutil.WriteFile(
+		filepath.Join(dir, "refs", "heads", "master"),
+		[]byte("aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa\n"),
+		0644,
+	), IsNil)
+	c.Assert(ioutil.WriteFile(
+		filepath.Join(dir, "HEAD"),
+		[]byte("ref: refs/heads/master\n"),
+		0644,
+	), IsNil)
+
+	loose, err := ReadLoose(dir)
+	c.Assert(err, IsNil)
+	c.Assert(loose, HasLen, 1)
+	c.Assert(loose[0].Name, Equals, "refs/heads/master")
+
+	resolved, err := ResolveSymbolic(dir, "HEAD")
+	c.Assert(err, IsNil)
+	c.Assert(resolved.Name, Equals, "refs/heads/master")
+	c.Assert(resolved.Target, Equals, "aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa")
+}
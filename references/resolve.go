@@ -0,0 +1,62 @@
+package references
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+)
+
+// ErrReferenceLoop is returned by ResolveSymbolic if it detects a cycle
+// while following a chain of symbolic references.
+var ErrReferenceLoop = errors.New("reference loop detected")
+
+// Lookup returns the Reference named name, trying a loose ref file
+// first and falling back to packed-refs.
+func Lookup(gitDir, name string) (*Reference, error) {
+	if name == "HEAD" {
+		return ReadHEAD(gitDir)
+	}
+
+	path := filepath.Join(gitDir, filepath.FromSlash(name))
+	if ref, err := readRefFile(name, path); err == nil {
+		return ref, nil
+	} else if !os.IsNotExist(err) {
+		return nil, err
+	}
+
+	packed, err := ReadPacked(gitDir)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, ref := range packed {
+		if ref.Name == name {
+			return ref, nil
+		}
+	}
+
+	return nil, os.ErrNotExist
+}
+
+// ResolveSymbolic follows a chain of symbolic references (as HEAD
+// usually is, "ref: refs/heads/master") starting at name, returning the
+// first Reference found that points directly at a hash.
+func ResolveSymbolic(gitDir, name string) (*Reference, error) {
+	seen := make(map[string]bool)
+	for {
+		if seen[name] {
+			return nil, ErrReferenceLoop
+		}
+		seen[name] = true
+
+		ref, err := Lookup(gitDir, name)
+		if err != nil {
+			return nil, err
+		}
+		if ref.Type != SymbolicRef {
+			return ref, nil
+		}
+
+		name = ref.Target
+	}
+}
@@ -0,0 +1,81 @@
+// Package git provides a repository-level API on top of the lower level
+// packages; it exposes a repository's references.
+package git
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/aknuds1/go-git/references"
+)
+
+// Repository gives access to the references stored under a single .git
+// directory. It has no methods for reading objects or packfiles.
+type Repository struct {
+	gitDir string
+}
+
+// NewRepository returns a Repository backed by the given .git directory.
+func NewRepository(gitDir string) *Repository {
+	return &Repository{gitDir: gitDir}
+}
+
+// Reference returns the reference named name, without following
+// symbolic references. Use ResolveSymbolic to follow a chain such as
+// HEAD down to the hash it ultimately points to.
+func (r *Repository) Reference(name string) (*references.Reference, error) {
+	return references.Lookup(r.gitDir, name)
+}
+
+// ResolveSymbolic follows symbolic references, such as HEAD, until it
+// reaches one that points directly at a hash.
+func (r *Repository) ResolveSymbolic(name string) (*references.Reference, error) {
+	return references.ResolveSymbolic(r.gitDir, name)
+}
+
+// References returns an iterator over every reference whose name starts
+// with prefix, merging loose refs with packed-refs. Loose refs take
+// precedence over a packed ref of the same name, matching git's own
+// resolution order.
+func (r *Repository) References(prefix string) (*references.ReferenceIter, error) {
+	loose, err := references.ReadLoose(r.gitDir)
+	if err != nil {
+		return nil, err
+	}
+
+	packed, err := references.ReadPacked(r.gitDir)
+	if err != nil {
+		return nil, err
+	}
+
+	byName := make(map[string]*references.Reference, len(loose)+len(packed))
+	for _, ref := range packed {
+		byName[ref.Name] = ref
+	}
+	for _, ref := range loose {
+		byName[ref.Name] = ref
+	}
+
+	var matched []*references.Reference
+	for name, ref := range byName {
+		if strings.HasPrefix(name, prefix) {
+			matched = append(matched, ref)
+		}
+	}
+
+	sort.Slice(matched, func(i, j int) bool {
+		return matched[i].Name < matched[j].Name
+	})
+
+	return references.NewReferenceIter(matched), nil
+}
+
+// Branches returns an iterator over every reference under refs/heads/.
+func (r *Repository) Branches() (*references.ReferenceIter, error) {
+	return r.References("refs/heads/")
+}
+
+// Tags returns an iterator over every reference under refs/tags/.
+func (r *Repository) Tags() (*references.ReferenceIter, error) {
+	return r.References("refs/tags/")
+}
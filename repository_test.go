@@ -0,0 +1,137 @@
+package git
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/aknuds1/go-git/references"
+	. "gopkg.in/check.v1"
+)
+
+func Test(t *testing.T) { TestingT(t) }
+
+type RepositorySuite struct {
+	dir string
+}
+
+var _ = Suite(&RepositorySuite{})
+
+func (s *RepositorySuite) SetUpTest(c *C) {
+	dir, err := ioutil.TempDir("", "go-git-repository")
+	c.Assert(err, IsNil)
+	s.dir = dir
+
+	c.Assert(os.MkdirAll(filepath.Join(dir, "refs", "heads"), 0755), IsNil)
+	c.Assert(ioutil.WriteFile(
+		filepath.Join(dir, "refs", "heads", "master"),
+		[]byte("aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa\n"),
+		0644,
+	), IsNil)
+	c.Assert(ioutil.WriteFile(
+		filepath.Join(dir, "refs", "heads", "zeta"),
+		[]byte("dddddddddddddddddddddddddddddddddddddddd\n"),
+		0644,
+	), IsNil)
+	c.Assert(ioutil.WriteFile(
+		filepath.Join(dir, "HEAD"),
+		[]byte("ref: refs/heads/master\n"),
+		0644,
+	), IsNil)
+
+	packed := strings.Join([]string{
+		"# pack-refs with: peeled fully-peeled sorted",
+		"bbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbbb refs/heads/old",
+		"cccccccccccccccccccccccccccccccccccccccc refs/tags/v1.0.0",
+		"",
+	}, "\n")
+	c.Assert(ioutil.WriteFile(filepath.Join(dir, "packed-refs"), []byte(packed), 0644), IsNil)
+}
+
+func (s *RepositorySuite) TearDownTest(c *C) {
+	os.RemoveAll(s.dir)
+}
+
+func (s *RepositorySuite) TestReference(c *C) {
+	repo := NewRepository(s.dir)
+
+	ref, err := repo.Reference("refs/heads/master")
+	c.Assert(err, IsNil)
+	c.Assert(ref.Target, Equals, "aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa")
+
+	ref, err = repo.Reference("refs/tags/v1.0.0")
+	c.Assert(err, IsNil)
+	c.Assert(ref.Target, Equals, "cccccccccccccccccccccccccccccccccccccccc")
+}
+
+func (s *RepositorySuite) TestResolveSymbolic(c *C) {
+	repo := NewRepository(s.dir)
+
+	ref, err := repo.ResolveSymbolic("HEAD")
+	c.Assert(err, IsNil)
+	c.Assert(ref.Name, Equals, "refs/heads/master")
+	c.Assert(ref.Target, Equals, "aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa")
+}
+
+func (s *RepositorySuite) TestBranchesMergesLooseAndPacked(c *C) {
+	repo := NewRepository(s.dir)
+
+	iter, err := repo.Branches()
+	c.Assert(err, IsNil)
+
+	names := map[string]bool{}
+	err = iter.ForEach(func(ref *references.Reference) error {
+		names[ref.Name] = true
+		return nil
+	})
+	c.Assert(err, IsNil)
+	c.Assert(names, HasLen, 3)
+	c.Assert(names["refs/heads/master"], Equals, true)
+	c.Assert(names["refs/heads/old"], Equals, true)
+	c.Assert(names["refs/heads/zeta"], Equals, true)
+}
+
+// TestBranchesReturnsSortedOrder guards against Branches (and the
+// References/Tags methods sharing its implementation) returning refs in
+// the randomized order Go map iteration would otherwise produce.
+func (s *RepositorySuite) TestBranchesReturnsSortedOrder(c *C) {
+	repo := NewRepository(s.dir)
+
+	var got []string
+	for i := 0; i < 5; i++ {
+		iter, err := repo.Branches()
+		c.Assert(err, IsNil)
+
+		var names []string
+		err = iter.ForEach(func(ref *references.Reference) error {
+			names = append(names, ref.Name)
+			return nil
+		})
+		c.Assert(err, IsNil)
+
+		if got == nil {
+			got = names
+		} else {
+			c.Assert(names, DeepEquals, got)
+		}
+	}
+
+	c.Assert(got, DeepEquals, []string{
+		"refs/heads/master",
+		"refs/heads/old",
+		"refs/heads/zeta",
+	})
+}
+
+func (s *RepositorySuite) TestTags(c *C) {
+	repo := NewRepository(s.dir)
+
+	iter, err := repo.Tags()
+	c.Assert(err, IsNil)
+
+	ref, err := iter.Next()
+	c.Assert(err, IsNil)
+	c.Assert(ref.Name, Equals, "refs/tags/v1.0.0")
+}
@@ -0,0 +1,222 @@
+// Package storage provides ObjectStorage implementations: a loose
+// object filesystem backend mirroring git's own .git/objects layout,
+// and an in-memory backend for tests.
+package storage
+
+import (
+	"bytes"
+	"compress/zlib"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+
+	"github.com/aknuds1/go-git/packfile"
+)
+
+// LooseStorage stores each object as an individual zlib-compressed file
+// under root/xx/yyyy..., exactly like git's .git/objects directory.
+type LooseStorage struct {
+	root string
+}
+
+// NewLooseStorage returns a LooseStorage rooted at root, typically a
+// repository's ".git/objects" directory.
+func NewLooseStorage(root string) *LooseStorage {
+	return &LooseStorage{root: root}
+}
+
+func (s *LooseStorage) path(hash string) string {
+	return filepath.Join(s.root, hash[:2], hash[2:])
+}
+
+// Has reports whether an object with the given hash is stored.
+func (s *LooseStorage) Has(hash string) bool {
+	_, err := os.Stat(s.path(hash))
+	return err == nil
+}
+
+// Get returns the object identified by hash along with a reader over
+// its content. Blob content is streamed straight off disk; every other
+// type is read fully so it can be parsed.
+func (s *LooseStorage) Get(hash string) (packfile.Object, io.ReadCloser, error) {
+	f, err := os.Open(s.path(hash))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	zr, err := zlib.NewReader(f)
+	if err != nil {
+		f.Close()
+		return nil, nil, err
+	}
+
+	objType, size, err := readLooseHeader(zr)
+	if err != nil {
+		zr.Close()
+		f.Close()
+		return nil, nil, err
+	}
+
+	if objType == "blob" {
+		return packfile.NewBlob(s, hash), &looseBlobReader{zr, f}, nil
+	}
+	defer zr.Close()
+	defer f.Close()
+
+	content, err := ioutil.ReadAll(io.LimitReader(zr, int64(size)))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	o, err := packfile.NewObject(objType, content)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return o, ioutil.NopCloser(bytes.NewReader(content)), nil
+}
+
+// Put stores o, reading its content from r, and returns its hash.
+func (s *LooseStorage) Put(o packfile.Object, r io.Reader) (string, error) {
+	content, err := ioutil.ReadAll(r)
+	if err != nil {
+		return "", err
+	}
+
+	hash := packfile.HashObject(o.Type(), content)
+	path := s.path(hash)
+
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return "", err
+	}
+
+	f, err := os.Create(path)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	zw := zlib.NewWriter(f)
+	defer zw.Close()
+
+	if _, err := fmt.Fprintf(zw, "%s %d\x00", o.Type(), len(content)); err != nil {
+		return "", err
+	}
+	if _, err := zw.Write(content); err != nil {
+		return "", err
+	}
+
+	return hash, nil
+}
+
+// Iter returns an iterator over every stored object of objType.
+func (s *LooseStorage) Iter(objType string) (packfile.ObjectIter, error) {
+	var hashes []string
+	err := filepath.Walk(s.root, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() {
+			return nil
+		}
+
+		dir := filepath.Base(filepath.Dir(path))
+		hashes = append(hashes, dir+filepath.Base(path))
+		return nil
+	})
+	if os.IsNotExist(err) {
+		return &looseObjectIter{storage: s}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var matched []string
+	for _, hash := range hashes {
+		o, r, err := s.Get(hash)
+		if err != nil {
+			continue
+		}
+		r.Close()
+
+		if o.Type() == objType {
+			matched = append(matched, hash)
+		}
+	}
+
+	return &looseObjectIter{storage: s, hashes: matched}, nil
+}
+
+// readLooseHeader reads a loose object's "type len\x00" header off r.
+func readLooseHeader(r io.Reader) (objType string, size int, err error) {
+	var header []byte
+	buf := make([]byte, 1)
+	for {
+		if _, err = r.Read(buf); err != nil {
+			return "", 0, err
+		}
+		if buf[0] == 0 {
+			break
+		}
+		header = append(header, buf[0])
+	}
+
+	parts := bytes.SplitN(header, []byte{' '}, 2)
+	if len(parts) != 2 {
+		return "", 0, fmt.Errorf("malformed loose object header %q", header)
+	}
+
+	size, err = strconv.Atoi(string(parts[1]))
+	if err != nil {
+		return "", 0, fmt.Errorf("malformed loose object header %q", header)
+	}
+
+	return string(parts[0]), size, nil
+}
+
+// looseBlobReader closes both the zlib reader and the underlying file
+// it wraps when it is closed; reaching the end of a blob's content
+// closes neither.
+type looseBlobReader struct {
+	zr io.ReadCloser
+	f  *os.File
+}
+
+func (r *looseBlobReader) Read(p []byte) (int, error) {
+	return r.zr.Read(p)
+}
+
+func (r *looseBlobReader) Close() error {
+	zerr := r.zr.Close()
+	ferr := r.f.Close()
+	if zerr != nil {
+		return zerr
+	}
+	return ferr
+}
+
+type looseObjectIter struct {
+	storage *LooseStorage
+	hashes  []string
+	pos     int
+}
+
+func (i *looseObjectIter) Next() (packfile.Object, error) {
+	if i.pos >= len(i.hashes) {
+		return nil, io.EOF
+	}
+
+	hash := i.hashes[i.pos]
+	i.pos++
+
+	o, r, err := i.storage.Get(hash)
+	if err != nil {
+		return nil, err
+	}
+	r.Close()
+
+	return o, nil
+}
@@ -0,0 +1,97 @@
+package storage
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"io/ioutil"
+
+	"github.com/aknuds1/go-git/packfile"
+)
+
+// ErrObjectNotFound is returned by MemoryStorage.Get when no object is
+// stored under the requested hash.
+var ErrObjectNotFound = errors.New("object not found")
+
+type memoryObject struct {
+	obj     packfile.Object
+	content []byte
+}
+
+// MemoryStorage is an in-memory ObjectStorage, mainly useful in tests.
+type MemoryStorage struct {
+	objects map[string]memoryObject
+}
+
+// NewMemoryStorage returns an empty MemoryStorage.
+func NewMemoryStorage() *MemoryStorage {
+	return &MemoryStorage{objects: make(map[string]memoryObject)}
+}
+
+// Has reports whether an object with the given hash is stored.
+func (s *MemoryStorage) Has(hash string) bool {
+	_, ok := s.objects[hash]
+	return ok
+}
+
+// Get returns the object identified by hash along with a reader over
+// its content.
+func (s *MemoryStorage) Get(hash string) (packfile.Object, io.ReadCloser, error) {
+	o, ok := s.objects[hash]
+	if !ok {
+		return nil, nil, ErrObjectNotFound
+	}
+
+	return s.objectFor(hash, o), ioutil.NopCloser(bytes.NewReader(o.content)), nil
+}
+
+// objectFor returns the Object to hand back for a stored entry. Blobs
+// are reconstructed fresh against hash, the key they were looked up
+// under, since the object passed to Put may have been built before its
+// real hash was known (e.g. a placeholder Blob).
+func (s *MemoryStorage) objectFor(hash string, o memoryObject) packfile.Object {
+	if o.obj.Type() == "blob" {
+		return packfile.NewBlob(s, hash)
+	}
+
+	return o.obj
+}
+
+// Put stores o, reading its content from r, and returns its hash.
+func (s *MemoryStorage) Put(o packfile.Object, r io.Reader) (string, error) {
+	content, err := ioutil.ReadAll(r)
+	if err != nil {
+		return "", err
+	}
+
+	hash := packfile.HashObject(o.Type(), content)
+	s.objects[hash] = memoryObject{obj: o, content: content}
+	return hash, nil
+}
+
+// Iter returns an iterator over every stored object of objType.
+func (s *MemoryStorage) Iter(objType string) (packfile.ObjectIter, error) {
+	var matched []packfile.Object
+	for hash, o := range s.objects {
+		if o.obj.Type() == objType {
+			matched = append(matched, s.objectFor(hash, o))
+		}
+	}
+
+	return &memoryObjectIter{objects: matched}, nil
+}
+
+type memoryObjectIter struct {
+	objects []packfile.Object
+	pos     int
+}
+
+func (i *memoryObjectIter) Next() (packfile.Object, error) {
+	if i.pos >= len(i.objects) {
+		return nil, io.EOF
+	}
+
+	o := i.objects[i.pos]
+	i.pos++
+	return o, nil
+}
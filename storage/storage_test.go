@@ -0,0 +1,115 @@
+package storage
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/aknuds1/go-git/packfile"
+	. "gopkg.in/check.v1"
+)
+
+func Test(t *testing.T) { TestingT(t) }
+
+type StorageSuite struct{}
+
+var _ = Suite(&StorageSuite{})
+
+func (s *StorageSuite) TestMemoryStoragePutGet(c *C) {
+	storage := NewMemoryStorage()
+
+	obj, err := packfile.NewObject("tree", []byte{})
+	c.Assert(err, IsNil)
+
+	hash, err := storage.Put(obj, bytes.NewReader([]byte{}))
+	c.Assert(err, IsNil)
+	c.Assert(storage.Has(hash), Equals, true)
+
+	got, r, err := storage.Get(hash)
+	c.Assert(err, IsNil)
+	defer r.Close()
+	c.Assert(got.Type(), Equals, "tree")
+
+	content, err := ioutil.ReadAll(r)
+	c.Assert(err, IsNil)
+	c.Assert(content, HasLen, 0)
+}
+
+func (s *StorageSuite) TestMemoryStorageIter(c *C) {
+	storage := NewMemoryStorage()
+
+	commit := &packfile.Commit{}
+	_, err := storage.Put(commit, bytes.NewReader([]byte("commit body")))
+	c.Assert(err, IsNil)
+
+	iter, err := storage.Iter("commit")
+	c.Assert(err, IsNil)
+
+	n := 0
+	for {
+		_, err := iter.Next()
+		if err != nil {
+			break
+		}
+		n++
+	}
+	c.Assert(n, Equals, 1)
+}
+
+func (s *StorageSuite) TestMemoryStorageBlobRoundTrip(c *C) {
+	storage := NewMemoryStorage()
+
+	placeholder := packfile.NewBlob(storage, "")
+	hash, err := storage.Put(placeholder, bytes.NewReader([]byte("hello world")))
+	c.Assert(err, IsNil)
+	c.Assert(hash, Equals, packfile.HashObject("blob", []byte("hello world")))
+
+	o, r, err := storage.Get(hash)
+	c.Assert(err, IsNil)
+	defer r.Close()
+
+	blob, ok := o.(*packfile.Blob)
+	c.Assert(ok, Equals, true)
+	c.Assert(blob.Hash(), Equals, hash)
+
+	content, err := ioutil.ReadAll(r)
+	c.Assert(err, IsNil)
+	c.Assert(string(content), Equals, "hello world")
+}
+
+func (s *StorageSuite) TestLooseStorageBlobRoundTrip(c *C) {
+	dir, err := ioutil.TempDir("", "go-git-storage")
+	c.Assert(err, IsNil)
+	defer os.RemoveAll(dir)
+
+	storage := NewLooseStorage(dir)
+
+	hash := packfile.HashObject("blob", []byte("hello world"))
+	placeholder := packfile.NewBlob(storage, hash)
+
+	writtenHash, err := storage.Put(placeholder, bytes.NewReader([]byte("hello world")))
+	c.Assert(err, IsNil)
+	c.Assert(writtenHash, Equals, hash)
+	c.Assert(storage.Has(hash), Equals, true)
+
+	o, r, err := storage.Get(hash)
+	c.Assert(err, IsNil)
+	defer r.Close()
+
+	blob, ok := o.(*packfile.Blob)
+	c.Assert(ok, Equals, true)
+	c.Assert(blob.Hash(), Equals, hash)
+
+	content, err := ioutil.ReadAll(r)
+	c.Assert(err, IsNil)
+	c.Assert(string(content), Equals, "hello world")
+
+	lazy, err := blob.Reader()
+	c.Assert(err, IsNil)
+	defer lazy.Close()
+
+	content, err = ioutil.ReadAll(lazy)
+	c.Assert(err, IsNil)
+	c.Assert(string(content), Equals, "hello world")
+}